@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+)
+
+var ErrNoAuthHeaderIncluded = errors.New("no authorization header included")
+var ErrMalformedAuthHeader = errors.New("malformed authorization header")
+
+// GetAPIKey extracts an API key from the headers of an HTTP request. It
+// is a thin wrapper around defaultRegistry, which matches the
+// Authorization scheme token case-insensitively (per RFC 7235) and
+// dispatches to that scheme's parser, so callers can uniformly
+// authenticate either static ApiKey requests or AWS4-HMAC-SHA256
+// (SigV4) signed requests. Applications that need additional schemes
+// should build their own SchemeRegistry.
+//
+// Examples:
+// Authorization: ApiKey {insert apikey here}
+// Authorization: AWS4-HMAC-SHA256 Credential={access key}/{date}/{region}/{service}/aws4_request, SignedHeaders={headers}, Signature={signature}
+func GetAPIKey(headers http.Header) (string, error) {
+	authHeader := headers.Get("Authorization")
+	if authHeader == "" {
+		return "", ErrNoAuthHeaderIncluded
+	}
+
+	creds, err := defaultRegistry.Parse(authHeader)
+	if err != nil {
+		return "", err
+	}
+
+	return creds.Principal(), nil
+}
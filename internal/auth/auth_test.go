@@ -68,20 +68,20 @@ func TestGetAPIKey(t *testing.T) {
 			errorContains: "malformed authorization header",
 		},
 		{
-			name: "case sensitive - lowercase apikey",
+			name: "scheme matched case-insensitively - lowercase apikey",
 			headers: http.Header{
 				"Authorization": {"apikey my-secret-api-key"},
 			},
-			expectedKey:   "",
-			errorContains: "malformed authorization header",
+			expectedKey:   "my-secret-api-key",
+			expectedError: nil,
 		},
 		{
-			name: "case sensitive - uppercase APIKEY",
+			name: "scheme matched case-insensitively - uppercase APIKEY",
 			headers: http.Header{
 				"Authorization": {"APIKEY my-secret-api-key"},
 			},
-			expectedKey:   "",
-			errorContains: "malformed authorization header",
+			expectedKey:   "my-secret-api-key",
+			expectedError: nil,
 		},
 		{
 			name: "ApiKey with multiple spaces in value",
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+)
+
+// GetAPIKeyWithOptions behaves like GetAPIKey, except that when
+// opts.CanonicalizeHeaders is set it compares every key of the incoming
+// header map against "authorization" case-insensitively, without
+// modifying headers, instead of calling Get. http.Header.Get only matches the
+// canonical "Authorization" key; headers that arrive non-canonicalized
+// (HTTP/2 delivers them lowercased on the wire, and some proxies emit
+// "authorization" in mixed case) would otherwise be missed entirely.
+func GetAPIKeyWithOptions(headers http.Header, opts Options) (string, error) {
+	if !opts.CanonicalizeHeaders {
+		return GetAPIKey(headers)
+	}
+
+	authHeader := lowercasedHeaderValue(headers, "authorization")
+	if authHeader == "" {
+		return "", ErrNoAuthHeaderIncluded
+	}
+
+	creds, err := defaultRegistry.Parse(authHeader)
+	if err != nil {
+		return "", err
+	}
+
+	return creds.Principal(), nil
+}
+
+// lowercasedHeaderValue returns the first value stored under a key of
+// headers whose lowercased form equals lowerName. headers is not
+// modified; when several keys match, which one is used is not defined.
+func lowercasedHeaderValue(headers http.Header, lowerName string) string {
+	for key, values := range headers {
+		if strings.ToLower(key) == lowerName && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
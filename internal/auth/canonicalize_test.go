@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetAPIKeyWithOptionsCanonicalization(t *testing.T) {
+	tests := []struct {
+		name        string
+		headerKey   string
+		opts        Options
+		expectedKey string
+		expectedErr error
+	}{
+		{
+			name:        "canonical key without canonicalization",
+			headerKey:   "Authorization",
+			opts:        Options{},
+			expectedKey: "my-secret-api-key",
+		},
+		{
+			name:        "lowercase key without canonicalization is missed",
+			headerKey:   "authorization",
+			opts:        Options{},
+			expectedErr: ErrNoAuthHeaderIncluded,
+		},
+		{
+			name:        "lowercase key with canonicalization",
+			headerKey:   "authorization",
+			opts:        Options{CanonicalizeHeaders: true},
+			expectedKey: "my-secret-api-key",
+		},
+		{
+			name:        "uppercase key with canonicalization",
+			headerKey:   "AUTHORIZATION",
+			opts:        Options{CanonicalizeHeaders: true},
+			expectedKey: "my-secret-api-key",
+		},
+		{
+			name:        "canonical key with canonicalization",
+			headerKey:   "Authorization",
+			opts:        Options{CanonicalizeHeaders: true},
+			expectedKey: "my-secret-api-key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{
+				tt.headerKey: {"ApiKey my-secret-api-key"},
+			}
+
+			key, err := GetAPIKeyWithOptions(headers, tt.opts)
+
+			if tt.expectedErr != nil {
+				if err != tt.expectedErr {
+					t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if key != tt.expectedKey {
+				t.Errorf("expected key %q, got %q", tt.expectedKey, key)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// DefaultTokenLookup configures GetAPIKeyFrom to read an ApiKey-scoped
+// Authorization header only, the same header GetAPIKey reads (though
+// the two functions differ on edge cases; see GetAPIKeyFrom).
+const DefaultTokenLookup = "header:Authorization:ApiKey"
+
+// ErrNoKeyFound is returned by GetAPIKeyFrom when none of the
+// configured TokenLookup sources yielded a key.
+var ErrNoKeyFound = errors.New("no api key found in request")
+
+// GetAPIKeyFrom extracts a credential from r by trying each source in
+// lookup, in order, and returning the first one found. lookup mirrors
+// Echo's CSRF middleware TokenLookup config: a comma-separated list of
+// "<type>:<name>[:<scheme>]" sources, for example:
+//
+//	header:Authorization:ApiKey   Authorization header, ApiKey scheme
+//	header:X-API-Key              X-API-Key header, taken verbatim
+//	query:api_key                 ?api_key= query parameter
+//	cookie:session                "session" cookie
+//
+// GetAPIKeyFrom is a separate code path from GetAPIKey, not a superset
+// of it: a header source with a scheme accepts only that scheme, so
+// DefaultTokenLookup rejects AWS4-HMAC-SHA256 headers that GetAPIKey
+// accepts; it requires a non-empty value after the scheme token,
+// and reports every miss as ErrNoKeyFound rather than
+// ErrNoAuthHeaderIncluded or ErrMalformedAuthHeader.
+func GetAPIKeyFrom(r *http.Request, lookup string) (string, error) {
+	for _, source := range strings.Split(lookup, ",") {
+		parts := strings.SplitN(strings.TrimSpace(source), ":", 3)
+		if len(parts) < 2 {
+			continue
+		}
+		scheme := ""
+		if len(parts) == 3 {
+			scheme = parts[2]
+		}
+
+		var (
+			key string
+			err error
+		)
+		switch parts[0] {
+		case "header":
+			key, err = apiKeyFromHeader(r.Header, parts[1], scheme)
+		case "query":
+			key, err = apiKeyFromQuery(r, parts[1])
+		case "cookie":
+			key, err = apiKeyFromCookie(r, parts[1])
+		default:
+			continue
+		}
+
+		if err == nil && key != "" {
+			return key, nil
+		}
+	}
+
+	return "", ErrNoKeyFound
+}
+
+func apiKeyFromHeader(headers http.Header, name, wantScheme string) (string, error) {
+	value := headers.Get(name)
+	if value == "" {
+		return "", ErrNoKeyFound
+	}
+	if wantScheme == "" {
+		return value, nil
+	}
+
+	creds, err := defaultRegistry.Parse(value)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(creds.Scheme(), wantScheme) {
+		return "", ErrMalformedAuthHeader
+	}
+
+	return creds.Principal(), nil
+}
+
+func apiKeyFromQuery(r *http.Request, name string) (string, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return "", ErrNoKeyFound
+	}
+	return value, nil
+}
+
+func apiKeyFromCookie(r *http.Request, name string) (string, error) {
+	cookie, err := r.Cookie(name)
+	if err != nil || cookie.Value == "" {
+		return "", ErrNoKeyFound
+	}
+	return cookie.Value, nil
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAPIKeyFrom(t *testing.T) {
+	tests := []struct {
+		name        string
+		lookup      string
+		setup       func(r *http.Request)
+		expectedKey string
+		expectedErr error
+	}{
+		{
+			name:   "authorization header, default lookup",
+			lookup: DefaultTokenLookup,
+			setup: func(r *http.Request) {
+				r.Header.Set("Authorization", "ApiKey my-secret-api-key")
+			},
+			expectedKey: "my-secret-api-key",
+		},
+		{
+			name:   "custom header",
+			lookup: "header:X-API-Key",
+			setup: func(r *http.Request) {
+				r.Header.Set("X-API-Key", "my-secret-api-key")
+			},
+			expectedKey: "my-secret-api-key",
+		},
+		{
+			name:   "query parameter",
+			lookup: "query:api_key",
+			setup: func(r *http.Request) {
+				q := r.URL.Query()
+				q.Set("api_key", "my-secret-api-key")
+				r.URL.RawQuery = q.Encode()
+			},
+			expectedKey: "my-secret-api-key",
+		},
+		{
+			name:   "cookie",
+			lookup: "cookie:session",
+			setup: func(r *http.Request) {
+				r.AddCookie(&http.Cookie{Name: "session", Value: "my-secret-api-key"})
+			},
+			expectedKey: "my-secret-api-key",
+		},
+		{
+			name:   "falls through to second source",
+			lookup: "header:Authorization:ApiKey,query:api_key",
+			setup: func(r *http.Request) {
+				q := r.URL.Query()
+				q.Set("api_key", "fallback-key")
+				r.URL.RawQuery = q.Encode()
+			},
+			expectedKey: "fallback-key",
+		},
+		{
+			name:        "no source matches",
+			lookup:      "header:Authorization:ApiKey",
+			setup:       func(r *http.Request) {},
+			expectedErr: ErrNoKeyFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			tt.setup(req)
+
+			key, err := GetAPIKeyFrom(req, tt.lookup)
+
+			if tt.expectedErr != nil {
+				if err != tt.expectedErr {
+					t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if key != tt.expectedKey {
+				t.Errorf("expected key %q, got %q", tt.expectedKey, key)
+			}
+		})
+	}
+}
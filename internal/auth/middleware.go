@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+type contextKey int
+
+const apiKeyContextKey contextKey = iota
+
+// HandlerFunc mirrors labstack/echo's handler shape, for frameworks that
+// thread a single (w, r) pair through middleware and bubble errors up
+// rather than writing directly to the ResponseWriter.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Options configures Middleware, MiddlewareFunc and GetAPIKeyWithOptions.
+type Options struct {
+	// Skipper, if set, is consulted for every request; when it returns
+	// true, auth is bypassed entirely and next is called directly.
+	Skipper func(r *http.Request) bool
+	// ErrorHandler, when set, replaces the default status-code response
+	// so callers can customize the error body.
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+	// Validator, when set, is called with the extracted key so callers
+	// can reject it against a datastore before the request proceeds.
+	Validator func(ctx context.Context, key string) error
+	// CanonicalizeHeaders, when set, makes GetAPIKeyWithOptions look up
+	// the Authorization header case-insensitively. See canonicalize.go.
+	CanonicalizeHeaders bool
+}
+
+// FromContext returns the API key injected into ctx by Middleware or
+// MiddlewareFunc, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(apiKeyContextKey).(string)
+	return key, ok
+}
+
+// Middleware authenticates each request with GetAPIKey and injects the
+// extracted key into the request context, short-circuiting with the
+// default Options: 401 for a missing Authorization header, 400 for a
+// malformed one. Use NewMiddleware for a skipper, error handler or
+// key validator.
+func Middleware(next http.Handler) http.Handler {
+	return NewMiddleware(Options{})(next)
+}
+
+// NewMiddleware builds an http.Handler middleware configured by opts.
+func NewMiddleware(opts Options) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if opts.Skipper != nil && opts.Skipper(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key, err := GetAPIKeyWithOptions(r.Header, opts)
+			if err != nil {
+				respondAuthError(w, r, opts, err)
+				return
+			}
+
+			if opts.Validator != nil {
+				if err := opts.Validator(r.Context(), key); err != nil {
+					respondAuthError(w, r, opts, err)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiKeyContextKey, key)))
+		})
+	}
+}
+
+// MiddlewareFunc is the HandlerFunc counterpart to Middleware, for
+// frameworks built around labstack/echo's handler shape. An auth
+// failure is written to the ResponseWriter (through ErrorHandler or the
+// default status response) and nil is returned; the error is not
+// passed up to the caller.
+func MiddlewareFunc(next HandlerFunc) HandlerFunc {
+	return NewMiddlewareFunc(Options{})(next)
+}
+
+// NewMiddlewareFunc builds a HandlerFunc middleware configured by opts.
+func NewMiddlewareFunc(opts Options) func(HandlerFunc) HandlerFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			if opts.Skipper != nil && opts.Skipper(r) {
+				return next(w, r)
+			}
+
+			key, err := GetAPIKeyWithOptions(r.Header, opts)
+			if err != nil {
+				respondAuthError(w, r, opts, err)
+				return nil
+			}
+
+			if opts.Validator != nil {
+				if err := opts.Validator(r.Context(), key); err != nil {
+					respondAuthError(w, r, opts, err)
+					return nil
+				}
+			}
+
+			return next(w, r.WithContext(context.WithValue(r.Context(), apiKeyContextKey, key)))
+		}
+	}
+}
+
+func respondAuthError(w http.ResponseWriter, r *http.Request, opts Options, err error) {
+	if opts.ErrorHandler != nil {
+		opts.ErrorHandler(w, r, err)
+		return
+	}
+
+	status := http.StatusUnauthorized
+	if errors.Is(err, ErrMalformedAuthHeader) {
+		status = http.StatusBadRequest
+	}
+	http.Error(w, err.Error(), status)
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		headers        http.Header
+		expectedStatus int
+		expectedKey    string
+	}{
+		{
+			name:           "no authorization header",
+			headers:        http.Header{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "malformed header",
+			headers: http.Header{
+				"Authorization": {"Bearer some-token"},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "valid key injected into context",
+			headers: http.Header{
+				"Authorization": {"ApiKey my-secret-api-key"},
+			},
+			expectedStatus: http.StatusOK,
+			expectedKey:    "my-secret-api-key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotKey string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotKey, _ = FromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header = tt.headers
+			rec := httptest.NewRecorder()
+
+			Middleware(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if gotKey != tt.expectedKey {
+				t.Errorf("expected key %q, got %q", tt.expectedKey, gotKey)
+			}
+		})
+	}
+}
+
+func TestMiddlewareSkipper(t *testing.T) {
+	opts := Options{
+		Skipper: func(r *http.Request) bool {
+			return r.URL.Path == "/healthz"
+		},
+	}
+
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+
+	NewMiddleware(opts)(next).ServeHTTP(rec, req)
+
+	if !called {
+		t.Error("expected skipped request to reach next handler")
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestMiddlewareValidator(t *testing.T) {
+	opts := Options{
+		Validator: func(ctx context.Context, key string) error {
+			if key != "allowed-key" {
+				return errors.New("key not found")
+			}
+			return nil
+		},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "ApiKey wrong-key")
+	rec := httptest.NewRecorder()
+
+	NewMiddleware(opts)(next).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
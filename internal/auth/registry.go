@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"strings"
+	"sync"
+)
+
+// Credentials is implemented by each scheme's parsed result so that
+// middleware can key off either the auth scheme or a caller-agnostic
+// principal without knowing the concrete credential type.
+type Credentials interface {
+	Scheme() string
+	Principal() string
+}
+
+// apiKeyCredentials is the Credentials implementation for the ApiKey scheme.
+type apiKeyCredentials string
+
+func (c apiKeyCredentials) Scheme() string    { return "ApiKey" }
+func (c apiKeyCredentials) Principal() string { return string(c) }
+
+// SchemeParser parses the portion of an Authorization header value that
+// follows the scheme token, e.g. the part after "ApiKey ".
+type SchemeParser func(value string) (Credentials, error)
+
+// SchemeRegistry maps Authorization scheme tokens, matched
+// case-insensitively per RFC 7235, to the parser that understands their
+// credential format. A registry from NewSchemeRegistry starts empty,
+// without the ApiKey and AWS4-HMAC-SHA256 parsers that GetAPIKey uses;
+// applications register every scheme they need (Bearer, Basic, ...).
+type SchemeRegistry struct {
+	mu      sync.RWMutex
+	parsers map[string]SchemeParser
+}
+
+// NewSchemeRegistry returns an empty SchemeRegistry.
+func NewSchemeRegistry() *SchemeRegistry {
+	return &SchemeRegistry{parsers: make(map[string]SchemeParser)}
+}
+
+// Register associates scheme with parser. scheme is matched
+// case-insensitively when Parse is later called.
+func (r *SchemeRegistry) Register(scheme string, parser SchemeParser) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.parsers[strings.ToLower(scheme)] = parser
+}
+
+// Parse splits authHeader into its scheme token and value, then
+// dispatches to the parser registered for that scheme.
+func (r *SchemeRegistry) Parse(authHeader string) (Credentials, error) {
+	scheme, value, ok := strings.Cut(authHeader, " ")
+	if !ok {
+		return nil, ErrMalformedAuthHeader
+	}
+
+	r.mu.RLock()
+	parser, ok := r.parsers[strings.ToLower(scheme)]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, ErrMalformedAuthHeader
+	}
+
+	return parser(value)
+}
+
+// defaultRegistry backs GetAPIKey. Applications that need additional
+// schemes should build their own SchemeRegistry rather than mutate this
+// one, since it is shared process-wide.
+var defaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *SchemeRegistry {
+	r := NewSchemeRegistry()
+	r.Register("ApiKey", parseAPIKey)
+	r.Register("AWS4-HMAC-SHA256", parseSigV4)
+	return r
+}
+
+func parseAPIKey(value string) (Credentials, error) {
+	key, _, _ := strings.Cut(value, " ")
+	return apiKeyCredentials(key), nil
+}
+
+func parseSigV4(value string) (Credentials, error) {
+	return parseSigV4Value(value)
+}
@@ -0,0 +1,76 @@
+package auth
+
+import "testing"
+
+type bearerCredentials string
+
+func (c bearerCredentials) Scheme() string    { return "Bearer" }
+func (c bearerCredentials) Principal() string { return string(c) }
+
+func TestSchemeRegistryRegisterAndParse(t *testing.T) {
+	r := NewSchemeRegistry()
+	r.Register("Bearer", func(value string) (Credentials, error) {
+		return bearerCredentials(value), nil
+	})
+
+	tests := []struct {
+		name              string
+		authHeader        string
+		expectedPrincipal string
+		expectedErr       error
+	}{
+		{
+			name:              "exact case match",
+			authHeader:        "Bearer some-token",
+			expectedPrincipal: "some-token",
+		},
+		{
+			name:              "case-insensitive scheme match",
+			authHeader:        "bearer some-token",
+			expectedPrincipal: "some-token",
+		},
+		{
+			name:        "unregistered scheme",
+			authHeader:  "ApiKey my-key",
+			expectedErr: ErrMalformedAuthHeader,
+		},
+		{
+			name:        "no scheme separator",
+			authHeader:  "Bearer",
+			expectedErr: ErrMalformedAuthHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds, err := r.Parse(tt.authHeader)
+
+			if tt.expectedErr != nil {
+				if err != tt.expectedErr {
+					t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if creds.Principal() != tt.expectedPrincipal {
+				t.Errorf("expected principal %q, got %q", tt.expectedPrincipal, creds.Principal())
+			}
+		})
+	}
+}
+
+func TestDefaultRegistryKeepsSigV4Dispatch(t *testing.T) {
+	creds, err := defaultRegistry.Parse("AWS4-HMAC-SHA256 Credential=AKIAEXAMPLE/20240115/us-east-1/s3/aws4_request, SignedHeaders=host, Signature=abc123")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if creds.Scheme() != "AWS4-HMAC-SHA256" {
+		t.Errorf("expected scheme %q, got %q", "AWS4-HMAC-SHA256", creds.Scheme())
+	}
+	if creds.Principal() != "AKIAEXAMPLE" {
+		t.Errorf("expected principal %q, got %q", "AKIAEXAMPLE", creds.Principal())
+	}
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGetSigV4Credentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		headers       http.Header
+		expected      SigV4Credentials
+		expectedError error
+	}{
+		{
+			name:          "no authorization header",
+			headers:       http.Header{},
+			expectedError: ErrNoAuthHeaderIncluded,
+		},
+		{
+			name: "valid SigV4 header",
+			headers: http.Header{
+				"Authorization": {"AWS4-HMAC-SHA256 Credential=AKIAEXAMPLE/20240115/us-east-1/s3/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=abcdef1234567890"},
+			},
+			expected: SigV4Credentials{
+				AccessKeyID:   "AKIAEXAMPLE",
+				Date:          "20240115",
+				Region:        "us-east-1",
+				Service:       "s3",
+				SignedHeaders: []string{"content-type", "host", "x-amz-date"},
+				Signature:     "abcdef1234567890",
+			},
+		},
+		{
+			name: "malformed header - not a credential scope",
+			headers: http.Header{
+				"Authorization": {"AWS4-HMAC-SHA256 garbage"},
+			},
+			expectedError: ErrMalformedAuthHeader,
+		},
+		{
+			name: "bad credential scope - short date",
+			headers: http.Header{
+				"Authorization": {"AWS4-HMAC-SHA256 Credential=AKIAEXAMPLE/2024/us-east-1/s3/aws4_request, SignedHeaders=host, Signature=abc"},
+			},
+			expectedError: ErrBadCredentialScope,
+		},
+		{
+			name: "bad credential scope - non-numeric date",
+			headers: http.Header{
+				"Authorization": {"AWS4-HMAC-SHA256 Credential=AKIAEXAMPLE/abcdefgh/us-east-1/s3/aws4_request, SignedHeaders=host, Signature=abc"},
+			},
+			expectedError: ErrBadCredentialScope,
+		},
+		{
+			name: "bad credential scope - invalid month and day",
+			headers: http.Header{
+				"Authorization": {"AWS4-HMAC-SHA256 Credential=AKIAEXAMPLE/20241399/us-east-1/s3/aws4_request, SignedHeaders=host, Signature=abc"},
+			},
+			expectedError: ErrBadCredentialScope,
+		},
+		{
+			name: "missing signed headers",
+			headers: http.Header{
+				"Authorization": {"AWS4-HMAC-SHA256 Credential=AKIAEXAMPLE/20240115/us-east-1/s3/aws4_request, SignedHeaders=, Signature=abc"},
+			},
+			expectedError: ErrMissingSignedHeaders,
+		},
+		{
+			name: "missing signature",
+			headers: http.Header{
+				"Authorization": {"AWS4-HMAC-SHA256 Credential=AKIAEXAMPLE/20240115/us-east-1/s3/aws4_request, SignedHeaders=host, Signature="},
+			},
+			expectedError: ErrMissingSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetSigV4Credentials(tt.headers)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if got.AccessKeyID != tt.expected.AccessKeyID ||
+				got.Date != tt.expected.Date ||
+				got.Region != tt.expected.Region ||
+				got.Service != tt.expected.Service ||
+				got.Signature != tt.expected.Signature ||
+				len(got.SignedHeaders) != len(tt.expected.SignedHeaders) {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+			for i := range got.SignedHeaders {
+				if got.SignedHeaders[i] != tt.expected.SignedHeaders[i] {
+					t.Errorf("expected signed header %q, got %q", tt.expected.SignedHeaders[i], got.SignedHeaders[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetAPIKeySigV4Dispatch(t *testing.T) {
+	headers := http.Header{
+		"Authorization": {"AWS4-HMAC-SHA256 Credential=AKIAEXAMPLE/20240115/us-east-1/s3/aws4_request, SignedHeaders=host, Signature=abc123"},
+	}
+
+	key, err := GetAPIKey(headers)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if key != "AKIAEXAMPLE" {
+		t.Errorf("expected key %q, got %q", "AKIAEXAMPLE", key)
+	}
+}
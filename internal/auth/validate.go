@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrEmptyAPIKey is returned when the Authorization header carries an
+// ApiKey scheme with no (or whitespace-only) key value. GetAPIKey itself
+// tolerates this for backwards compatibility, but ValidateAPIKey treats
+// it as a hard rejection.
+var ErrEmptyAPIKey = errors.New("empty api key")
+
+// ErrInvalidAPIKey is returned by a KeyValidator when the presented key
+// does not match any known principal.
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// Principal identifies the caller behind a validated API key.
+type Principal struct {
+	ID string
+}
+
+// KeyValidator checks a presented API key against stored credentials
+// and resolves it to a Principal. Implementations must use a
+// constant-time comparison against the stored (hashed) key so that
+// ValidateAPIKey is safe against timing attacks.
+type KeyValidator interface {
+	Validate(ctx context.Context, key string) (Principal, error)
+}
+
+// KeyValidatorFunc adapts a function to a KeyValidator.
+type KeyValidatorFunc func(ctx context.Context, key string) (Principal, error)
+
+func (f KeyValidatorFunc) Validate(ctx context.Context, key string) (Principal, error) {
+	return f(ctx, key)
+}
+
+// ValidateAPIKey extracts the API key from headers with GetAPIKey and
+// resolves it to a Principal via validator, rejecting empty or
+// whitespace-only keys outright rather than handing them to validator.
+func ValidateAPIKey(headers http.Header, validator KeyValidator) (Principal, error) {
+	key, err := GetAPIKey(headers)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	if strings.TrimSpace(key) == "" {
+		return Principal{}, ErrEmptyAPIKey
+	}
+
+	return validator.Validate(context.Background(), key)
+}
+
+// HashAPIKey derives a salted HMAC-SHA256 digest of key, hex-encoded.
+// It is intended for keys compared via NewHMACValidator; for
+// password-like secrets that must resist offline brute force, hash the
+// key with bcrypt instead and store that hash.
+func HashAPIKey(key, salt string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewHMACValidator returns a KeyValidator that compares the HMAC-SHA256
+// of a presented key against hashedKeys (as produced by HashAPIKey with
+// string(secret) as the salt; other salts never match) using crypto/subtle.ConstantTimeCompare, so lookup time does not leak
+// how many characters of a guess matched.
+func NewHMACValidator(secret []byte, hashedKeys map[string]Principal) KeyValidator {
+	return KeyValidatorFunc(func(_ context.Context, key string) (Principal, error) {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(key))
+		digest := hex.EncodeToString(mac.Sum(nil))
+
+		for hashed, principal := range hashedKeys {
+			if subtle.ConstantTimeCompare([]byte(digest), []byte(hashed)) == 1 {
+				return principal, nil
+			}
+		}
+
+		return Principal{}, ErrInvalidAPIKey
+	})
+}
+
+// NewBcryptValidator returns a KeyValidator that checks a presented key
+// against bcrypt hashes (as produced by bcrypt.GenerateFromPassword),
+// which is the right choice when keys are user-chosen and may be weak,
+// since bcrypt is deliberately slow to brute force.
+func NewBcryptValidator(hashedKeys map[string]Principal) KeyValidator {
+	return KeyValidatorFunc(func(_ context.Context, key string) (Principal, error) {
+		for hashed, principal := range hashedKeys {
+			if bcrypt.CompareHashAndPassword([]byte(hashed), []byte(key)) == nil {
+				return principal, nil
+			}
+		}
+
+		return Principal{}, ErrInvalidAPIKey
+	})
+}
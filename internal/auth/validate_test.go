@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestValidateAPIKey(t *testing.T) {
+	secret := []byte("test-secret")
+	hashedKeys := map[string]Principal{
+		HashAPIKey("good-key", string(secret)): {ID: "user-1"},
+	}
+	validator := NewHMACValidator(secret, hashedKeys)
+
+	tests := []struct {
+		name          string
+		headers       http.Header
+		expectedID    string
+		expectedError error
+	}{
+		{
+			name: "valid key",
+			headers: http.Header{
+				"Authorization": {"ApiKey good-key"},
+			},
+			expectedID: "user-1",
+		},
+		{
+			name: "unknown key",
+			headers: http.Header{
+				"Authorization": {"ApiKey wrong-key"},
+			},
+			expectedError: ErrInvalidAPIKey,
+		},
+		{
+			name: "empty key after space is rejected",
+			headers: http.Header{
+				"Authorization": {"ApiKey "},
+			},
+			expectedError: ErrEmptyAPIKey,
+		},
+		{
+			name: "whitespace-only key is rejected",
+			headers: http.Header{
+				"Authorization": {"ApiKey    "},
+			},
+			expectedError: ErrEmptyAPIKey,
+		},
+		{
+			name:          "no authorization header",
+			headers:       http.Header{},
+			expectedError: ErrNoAuthHeaderIncluded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := ValidateAPIKey(tt.headers, validator)
+
+			if tt.expectedError != nil {
+				if err != tt.expectedError {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if principal.ID != tt.expectedID {
+				t.Errorf("expected principal %q, got %q", tt.expectedID, principal.ID)
+			}
+		})
+	}
+}
+
+func TestNewBcryptValidator(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("good-key"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash key: %v", err)
+	}
+
+	validator := NewBcryptValidator(map[string]Principal{
+		string(hash): {ID: "user-1"},
+	})
+
+	principal, err := validator.Validate(nil, "good-key")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if principal.ID != "user-1" {
+		t.Errorf("expected principal %q, got %q", "user-1", principal.ID)
+	}
+
+	if _, err := validator.Validate(nil, "wrong-key"); err != ErrInvalidAPIKey {
+		t.Errorf("expected %v, got %v", ErrInvalidAPIKey, err)
+	}
+}
+
+func BenchmarkHMACValidatorMismatchedPrefix(b *testing.B) {
+	secret := []byte("bench-secret")
+	hashedKeys := map[string]Principal{
+		HashAPIKey("correct-horse-battery-staple", string(secret)): {ID: "user-1"},
+	}
+	validator := NewHMACValidator(secret, hashedKeys)
+
+	guesses := []string{
+		"a",
+		"aaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		"correct-horse-battery-staplx",
+		"zzzzzzzzzzzzzzzzzzzzzzzzzzzz",
+	}
+
+	for i := 0; i < b.N; i++ {
+		guess := guesses[i%len(guesses)]
+		_, _ = validator.Validate(nil, guess)
+	}
+}